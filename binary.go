@@ -0,0 +1,360 @@
+package closestmatch
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"unsafe"
+)
+
+var binaryMagic = [4]byte{'C', 'M', 'B', '1'}
+
+const binaryVersion = 1
+
+// SaveBinary writes cm to filename using a compact, fixed-layout binary
+// format: a small header, a deduplicated string table, the substring
+// postings (sorted ID runs, delta-varint encoded), and the ID table.
+// Unlike Save, this format is not self-describing for Data: the caller
+// must supply an encode function, and pass the matching decode function
+// to LoadBinary or LoadBinaryMmap. JSON Save/Load remain available
+// unchanged for callers that don't need this format.
+func (cm *ClosestMatch[Data]) SaveBinary(filename string, encode func(Data) ([]byte, error)) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	if err := cm.writeBinary(w, encode); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// LoadBinary reads a file previously written by SaveBinary.
+func LoadBinary[Data any](filename string, decode func([]byte) (Data, error)) (*ClosestMatch[Data], error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return decodeBinary[Data](data, decode)
+}
+
+// stringPool deduplicates strings written to a binary file, assigning
+// each distinct string a stable index.
+type stringPool struct {
+	index map[string]uint32
+	list  []string
+}
+
+func newStringPool() *stringPool {
+	return &stringPool{index: make(map[string]uint32)}
+}
+
+func (p *stringPool) add(s string) uint32 {
+	if i, ok := p.index[s]; ok {
+		return i
+	}
+	i := uint32(len(p.list))
+	p.index[s] = i
+	p.list = append(p.list, s)
+	return i
+}
+
+func (cm *ClosestMatch[Data]) writeBinary(w io.Writer, encode func(Data) ([]byte, error)) error {
+	pool := newStringPool()
+	for substring := range cm.SubstringToID {
+		pool.add(substring)
+	}
+
+	ids := make([]uint32, 0, len(cm.ID))
+	for id := range cm.ID {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		pool.add(cm.ID[id].Key)
+	}
+
+	if _, err := w.Write(binaryMagic[:]); err != nil {
+		return err
+	}
+	if _, err := w.Write([]byte{binaryVersion, byte(cm.Scoring)}); err != nil {
+		return err
+	}
+
+	if err := writeUvarint(w, uint64(len(cm.SubstringSizes))); err != nil {
+		return err
+	}
+	for _, size := range cm.SubstringSizes {
+		if err := writeVarint(w, int64(size)); err != nil {
+			return err
+		}
+	}
+
+	if err := writeUvarint(w, uint64(len(pool.list))); err != nil {
+		return err
+	}
+	for _, s := range pool.list {
+		if err := writeBlob(w, []byte(s)); err != nil {
+			return err
+		}
+	}
+
+	substrings := make([]string, 0, len(cm.SubstringToID))
+	for s := range cm.SubstringToID {
+		substrings = append(substrings, s)
+	}
+	sort.Strings(substrings)
+
+	if err := writeUvarint(w, uint64(len(substrings))); err != nil {
+		return err
+	}
+	for _, substring := range substrings {
+		postings := cm.SubstringToID[substring]
+		sortedIDs := make([]uint32, 0, len(postings))
+		for id := range postings {
+			sortedIDs = append(sortedIDs, id)
+		}
+		sort.Slice(sortedIDs, func(i, j int) bool { return sortedIDs[i] < sortedIDs[j] })
+
+		if err := writeUvarint(w, uint64(pool.index[substring])); err != nil {
+			return err
+		}
+		if err := writeUvarint(w, uint64(len(sortedIDs))); err != nil {
+			return err
+		}
+		var prev uint32
+		for _, id := range sortedIDs {
+			if err := writeUvarint(w, uint64(id-prev)); err != nil {
+				return err
+			}
+			prev = id
+		}
+	}
+
+	if err := writeUvarint(w, uint64(len(ids))); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		info := cm.ID[id]
+		data, err := encode(info.Data)
+		if err != nil {
+			return fmt.Errorf("closestmatch: encoding data for %q: %w", info.Key, err)
+		}
+		if err := writeUvarint(w, uint64(id)); err != nil {
+			return err
+		}
+		if err := writeUvarint(w, uint64(pool.index[info.Key])); err != nil {
+			return err
+		}
+		if err := writeUvarint(w, uint64(info.NumSubstrings)); err != nil {
+			return err
+		}
+		if err := writeBlob(w, data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// decodeBinary decodes data (the full contents of a SaveBinary file)
+// into a ClosestMatch. Keys and substrings are built as zero-copy
+// string views over data via unsafeString, rather than copied: callers
+// that pass a mapping they control the lifetime of (LoadBinaryMmap) get
+// a ClosestMatch that costs no string allocations to warm up; callers
+// that pass an owned buffer (LoadBinary's os.ReadFile result) keep it
+// alive automatically for as long as the returned ClosestMatch is
+// reachable, since Go's GC follows the pointers those views hold.
+func decodeBinary[Data any](data []byte, decode func([]byte) (Data, error)) (*ClosestMatch[Data], error) {
+	if len(data) < 6 || !bytes.Equal(data[:4], binaryMagic[:]) {
+		return nil, fmt.Errorf("closestmatch: not a closestmatch binary file")
+	}
+	if data[4] != binaryVersion {
+		return nil, fmt.Errorf("closestmatch: unsupported binary version %d", data[4])
+	}
+
+	cm := new(ClosestMatch[Data])
+	cm.Scoring = Scoring(data[5])
+
+	r := &byteReader{data: data, pos: 6}
+
+	numSizes, err := r.uvarint()
+	if err != nil {
+		return nil, err
+	}
+	cm.SubstringSizes = make([]int, numSizes)
+	for i := range cm.SubstringSizes {
+		v, err := r.varint()
+		if err != nil {
+			return nil, err
+		}
+		cm.SubstringSizes[i] = int(v)
+	}
+
+	numStrings, err := r.uvarint()
+	if err != nil {
+		return nil, err
+	}
+	pool := make([]string, numStrings)
+	for i := range pool {
+		b, err := r.blob()
+		if err != nil {
+			return nil, err
+		}
+		pool[i] = unsafeString(b)
+	}
+
+	numSubstrings, err := r.uvarint()
+	if err != nil {
+		return nil, err
+	}
+	cm.SubstringToID = make(map[string]map[uint32]struct{}, numSubstrings)
+	for i := uint64(0); i < numSubstrings; i++ {
+		idx, err := r.uvarint()
+		if err != nil {
+			return nil, err
+		}
+		if idx >= uint64(len(pool)) {
+			return nil, fmt.Errorf("closestmatch: corrupt string index")
+		}
+		count, err := r.uvarint()
+		if err != nil {
+			return nil, err
+		}
+
+		ids := make(map[uint32]struct{}, count)
+		var prev uint32
+		for j := uint64(0); j < count; j++ {
+			delta, err := r.uvarint()
+			if err != nil {
+				return nil, err
+			}
+			prev += uint32(delta)
+			ids[prev] = struct{}{}
+		}
+		cm.SubstringToID[pool[idx]] = ids
+	}
+
+	numIDs, err := r.uvarint()
+	if err != nil {
+		return nil, err
+	}
+	cm.ID = make(map[uint32]IDInfo[Data], numIDs)
+	for i := uint64(0); i < numIDs; i++ {
+		id, err := r.uvarint()
+		if err != nil {
+			return nil, err
+		}
+		keyIdx, err := r.uvarint()
+		if err != nil {
+			return nil, err
+		}
+		if keyIdx >= uint64(len(pool)) {
+			return nil, fmt.Errorf("closestmatch: corrupt string index")
+		}
+		numSub, err := r.uvarint()
+		if err != nil {
+			return nil, err
+		}
+		blob, err := r.blob()
+		if err != nil {
+			return nil, err
+		}
+		value, err := decode(blob)
+		if err != nil {
+			return nil, fmt.Errorf("closestmatch: decoding data for %q: %w", pool[keyIdx], err)
+		}
+
+		cm.ID[uint32(id)] = IDInfo[Data]{
+			Key:           pool[keyIdx],
+			NumSubstrings: int(numSub),
+			Data:          value,
+		}
+	}
+
+	if cm.Scoring == ScoringTFIDF {
+		cm.buildIDF()
+	}
+
+	return cm, nil
+}
+
+func writeUvarint(w io.Writer, v uint64) error {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	_, err := w.Write(buf[:n])
+	return err
+}
+
+func writeVarint(w io.Writer, v int64) error {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(buf[:], v)
+	_, err := w.Write(buf[:n])
+	return err
+}
+
+func writeBlob(w io.Writer, b []byte) error {
+	if err := writeUvarint(w, uint64(len(b))); err != nil {
+		return err
+	}
+	_, err := w.Write(b)
+	return err
+}
+
+// byteReader is a minimal cursor over an in-memory buffer, used to
+// decode a binary file whether it came from os.ReadFile or an mmap.
+type byteReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *byteReader) uvarint() (uint64, error) {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	if n <= 0 {
+		return 0, fmt.Errorf("closestmatch: corrupt varint at offset %d", r.pos)
+	}
+	r.pos += n
+	return v, nil
+}
+
+func (r *byteReader) varint() (int64, error) {
+	v, n := binary.Varint(r.data[r.pos:])
+	if n <= 0 {
+		return 0, fmt.Errorf("closestmatch: corrupt varint at offset %d", r.pos)
+	}
+	r.pos += n
+	return v, nil
+}
+
+func (r *byteReader) bytes(n int) ([]byte, error) {
+	if n < 0 || r.pos+n > len(r.data) {
+		return nil, fmt.Errorf("closestmatch: truncated binary data")
+	}
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return b, nil
+}
+
+func (r *byteReader) blob() ([]byte, error) {
+	n, err := r.uvarint()
+	if err != nil {
+		return nil, err
+	}
+	return r.bytes(int(n))
+}
+
+// unsafeString views b as a string without copying it. The caller must
+// ensure b is not mutated for as long as the returned string is live.
+func unsafeString(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	return unsafe.String(&b[0], len(b))
+}
@@ -0,0 +1,57 @@
+//go:build unix
+
+package closestmatch
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// LoadBinaryMmap memory-maps filename (as written by SaveBinary) and
+// decodes it directly from the mapping, so keys and substrings are
+// string views over the mapped file rather than copies. The postings
+// are still decoded into newly allocated maps, as in LoadBinary. Call
+// CloseMmap on the result once it is no longer needed to release the
+// mapping.
+func LoadBinaryMmap[Data any](filename string, decode func([]byte) (Data, error)) (*ClosestMatch[Data], error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() == 0 {
+		return nil, fmt.Errorf("closestmatch: %s is empty", filename)
+	}
+
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(info.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, fmt.Errorf("closestmatch: mmap %s: %w", filename, err)
+	}
+
+	cm, err := decodeBinary[Data](data, decode)
+	if err != nil {
+		syscall.Munmap(data)
+		return nil, err
+	}
+	cm.mmapData = data
+	return cm, nil
+}
+
+// CloseMmap unmaps the memory backing a ClosestMatch loaded via
+// LoadBinaryMmap, invalidating any Key or Data strings/bytes still
+// referencing it. It is a no-op for a ClosestMatch loaded any other
+// way.
+func (cm *ClosestMatch[Data]) CloseMmap() error {
+	if cm.mmapData == nil {
+		return nil
+	}
+	data := cm.mmapData
+	cm.mmapData = nil
+	return syscall.Munmap(data)
+}
@@ -0,0 +1,14 @@
+//go:build !unix
+
+package closestmatch
+
+// LoadBinaryMmap falls back to LoadBinary on platforms without mmap
+// support. The result owns its data outright, so CloseMmap is a no-op.
+func LoadBinaryMmap[Data any](filename string, decode func([]byte) (Data, error)) (*ClosestMatch[Data], error) {
+	return LoadBinary[Data](filename, decode)
+}
+
+// CloseMmap is a no-op on platforms without mmap support.
+func (cm *ClosestMatch[Data]) CloseMmap() error {
+	return nil
+}
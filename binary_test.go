@@ -0,0 +1,103 @@
+package closestmatch
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func encodeTestData(v int) ([]byte, error) { return json.Marshal(v) }
+
+func decodeTestData(b []byte) (int, error) {
+	var v int
+	err := json.Unmarshal(b, &v)
+	return v, err
+}
+
+func TestSaveLoadBinary(t *testing.T) {
+	words := map[string]int{
+		"hello world":   1,
+		"goodbye world": 2,
+		"help me":       3,
+		"apple pie":     4,
+	}
+	cm := New(words, []int{2, 3, 4}, WithScoring(ScoringTFIDF))
+
+	path := filepath.Join(t.TempDir(), "closestmatch.bin")
+	if err := cm.SaveBinary(path, encodeTestData); err != nil {
+		t.Fatalf("SaveBinary: %v", err)
+	}
+
+	loaded, err := LoadBinary[int](path, decodeTestData)
+	if err != nil {
+		t.Fatalf("LoadBinary: %v", err)
+	}
+
+	if loaded.Scoring != cm.Scoring {
+		t.Fatalf("Scoring mismatch: got %v, want %v", loaded.Scoring, cm.Scoring)
+	}
+	if len(loaded.ID) != len(cm.ID) {
+		t.Fatalf("ID count mismatch: got %d, want %d", len(loaded.ID), len(cm.ID))
+	}
+	for id, info := range cm.ID {
+		li, ok := loaded.ID[id]
+		if !ok || li.Key != info.Key || li.Data != info.Data || li.NumSubstrings != info.NumSubstrings {
+			t.Fatalf("ID %d mismatch: got %+v, want %+v", id, li, info)
+		}
+	}
+	if len(loaded.SubstringToID) != len(cm.SubstringToID) {
+		t.Fatalf("substring count mismatch: got %d, want %d", len(loaded.SubstringToID), len(cm.SubstringToID))
+	}
+	for s, ids := range cm.SubstringToID {
+		lids, ok := loaded.SubstringToID[s]
+		if !ok || len(lids) != len(ids) {
+			t.Fatalf("postings mismatch for %q", s)
+		}
+		for id := range ids {
+			if _, ok := lids[id]; !ok {
+				t.Fatalf("missing id %d for substring %q", id, s)
+			}
+		}
+	}
+
+	if got := loaded.Closest("hello wrld"); got != "hello world" {
+		t.Fatalf("Closest on loaded index: got %q, want %q", got, "hello world")
+	}
+}
+
+func TestLoadBinaryMmap(t *testing.T) {
+	words := map[string]int{"hello world": 1, "goodbye world": 2, "help me": 3}
+	cm := New(words, []int{2, 3, 4})
+
+	path := filepath.Join(t.TempDir(), "closestmatch.bin")
+	if err := cm.SaveBinary(path, encodeTestData); err != nil {
+		t.Fatalf("SaveBinary: %v", err)
+	}
+
+	mm, err := LoadBinaryMmap[int](path, decodeTestData)
+	if err != nil {
+		t.Fatalf("LoadBinaryMmap: %v", err)
+	}
+	defer mm.CloseMmap()
+
+	if got := mm.Closest("hello wrld"); got != "hello world" {
+		t.Fatalf("Closest on mmap-loaded index: got %q, want %q", got, "hello world")
+	}
+	for id, info := range cm.ID {
+		mi, ok := mm.ID[id]
+		if !ok || mi.Key != info.Key || mi.Data != info.Data {
+			t.Fatalf("ID %d mismatch after mmap load: got %+v, want %+v", id, mi, info)
+		}
+	}
+}
+
+func TestLoadBinaryRejectsForeignFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "not-closestmatch.bin")
+	if err := os.WriteFile(path, []byte("not a closestmatch file"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadBinary[int](path, decodeTestData); err == nil {
+		t.Fatal("expected LoadBinary to reject a file without the binary magic")
+	}
+}
@@ -3,14 +3,47 @@ package closestmatch
 import (
 	"compress/gzip"
 	"encoding/json"
+	"math"
 	"math/rand"
 	"os"
 	"sort"
 	"strings"
+	"sync"
 )
 
 const letters = "abcdefghijklmnopqrstuvwxyzöäüß"
 
+// Scoring selects the algorithm worker uses to turn substring matches
+// into a per-candidate score.
+type Scoring int
+
+const (
+	// ScoringLegacy is the original bag-of-substrings weighting, where
+	// every matched substring contributes 1 + 1000/len(key) +
+	// 1000/len(ids), len(ids) being the number of keys containing that
+	// substring. This is the default.
+	ScoringLegacy Scoring = iota
+	// ScoringTFIDF weights each substring by its inverse document
+	// frequency across the corpus, so common n-grams (which appear in
+	// most keys) contribute less than rare ones, and normalizes the
+	// final score by the candidate key's substring-IDF norm.
+	ScoringTFIDF
+)
+
+// Option configures a ClosestMatch at construction time.
+type Option func(*options)
+
+type options struct {
+	scoring Scoring
+}
+
+// WithScoring selects the scoring algorithm used by ClosestMatch. The
+// default, if unspecified, is ScoringLegacy, which matches the
+// behavior of this package before TF-IDF scoring was introduced.
+func WithScoring(s Scoring) Option {
+	return func(o *options) { o.scoring = s }
+}
+
 // ClosestMatch is the structure that contains the
 // substring sizes and carrys a map of the substrings for
 // easy lookup
@@ -18,6 +51,26 @@ type ClosestMatch[Data any] struct {
 	SubstringSizes []int
 	SubstringToID  map[string]map[uint32]struct{}
 	ID             map[uint32]IDInfo[Data]
+
+	Scoring Scoring
+	// IDF holds the inverse document frequency of each substring,
+	// log(1 + N/df(s)), populated only when Scoring is ScoringTFIDF.
+	IDF map[string]float64
+	// IDFNorm holds, per ID, the L2 norm of the IDF values of that
+	// key's substrings, used to normalize its TF-IDF score.
+	IDFNorm map[uint32]float64
+
+	// PrefixKeys holds every key, lowercased, sorted ascending, and
+	// PrefixIDs holds the matching ID for each entry of PrefixKeys.
+	// Both are built lazily on first use by MatchPrefix or
+	// MatchPrefixCmp.
+	PrefixKeys []string
+	PrefixIDs  []uint32
+	prefixOnce sync.Once
+
+	// mmapData, if non-nil, is the memory mapping backing a
+	// ClosestMatch loaded via LoadBinaryMmap; see CloseMmap.
+	mmapData []byte
 }
 
 // IDInfo carries the information about the keys
@@ -28,11 +81,17 @@ type IDInfo[Data any] struct {
 }
 
 // New returns a new structure for performing closest matches
-func New[Data any](possible map[string]Data, subsetSize []int) *ClosestMatch[Data] {
+func New[Data any](possible map[string]Data, subsetSize []int, opts ...Option) *ClosestMatch[Data] {
+	o := options{scoring: ScoringLegacy}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	cm := new(ClosestMatch[Data])
 	cm.SubstringSizes = subsetSize
 	cm.SubstringToID = make(map[string]map[uint32]struct{})
 	cm.ID = make(map[uint32]IDInfo[Data])
+	cm.Scoring = o.scoring
 	i := 0
 	for k, m := range possible {
 		substrings := cm.splitWord(strings.ToLower(k))
@@ -46,9 +105,37 @@ func New[Data any](possible map[string]Data, subsetSize []int) *ClosestMatch[Dat
 		i++
 	}
 
+	if cm.Scoring == ScoringTFIDF {
+		cm.buildIDF()
+	}
+
 	return cm
 }
 
+// buildIDF precomputes the inverse document frequency of every indexed
+// substring and, from that, the per-key IDF norm used to normalize
+// TF-IDF scores.
+func (cm *ClosestMatch[Data]) buildIDF() {
+	n := float64(len(cm.ID))
+	cm.IDF = make(map[string]float64, len(cm.SubstringToID))
+	for substring, ids := range cm.SubstringToID {
+		cm.IDF[substring] = math.Log(1 + n/float64(len(ids)))
+	}
+
+	sumSquares := make(map[uint32]float64, len(cm.ID))
+	for substring, ids := range cm.SubstringToID {
+		idf := cm.IDF[substring]
+		for id := range ids {
+			sumSquares[id] += idf * idf
+		}
+	}
+
+	cm.IDFNorm = make(map[uint32]float64, len(sumSquares))
+	for id, sum := range sumSquares {
+		cm.IDFNorm[id] = math.Sqrt(sum)
+	}
+}
+
 // Load can load a previously saved ClosestMatch object from disk
 func Load[Data any](filename string) (*ClosestMatch[Data], error) {
 	cm := new(ClosestMatch[Data])
@@ -87,12 +174,48 @@ type workerResult[Data any] struct {
 	Data  Data
 }
 
+// tfidfScale brings the TF-IDF score, which is normally in the 0-few
+// range, onto roughly the same scale as the legacy 1000-ish scores so
+// that Match.Value remains a meaningful int across both scoring modes.
+const tfidfScale = 1000
+
 func (cm *ClosestMatch[Data]) worker(id int, jobs <-chan job, results chan<- result[Data]) {
 	for j := range jobs {
 		m := make(map[string]workerResult[Data])
-		if ids, ok := cm.SubstringToID[j.substring]; ok {
+		ids, ok := cm.SubstringToID[j.substring]
+		if !ok {
+			results <- result[Data]{m: m}
+			continue
+		}
+
+		switch cm.Scoring {
+		case ScoringTFIDF:
+			idf := cm.IDF[j.substring]
+			for id := range ids {
+				if j.filter != nil && !j.filter(cm.ID[id].Key) {
+					continue
+				}
+
+				score := idf
+				if norm := cm.IDFNorm[id]; norm > 0 {
+					score /= norm
+				}
+
+				key := cm.ID[id].Key
+				if _, ok2 := m[key]; !ok2 {
+					m[key] = workerResult[Data]{Data: cm.ID[id].Data}
+				}
+				item := m[key]
+				item.Value += int(score * tfidfScale)
+				m[key] = item
+			}
+		default:
 			weight := 1000 / len(ids)
 			for id := range ids {
+				if j.filter != nil && !j.filter(cm.ID[id].Key) {
+					continue
+				}
+
 				if _, ok2 := m[cm.ID[id].Key]; !ok2 {
 					m[cm.ID[id].Key] = workerResult[Data]{Value: 0, Data: cm.ID[id].Data}
 				}
@@ -107,6 +230,9 @@ func (cm *ClosestMatch[Data]) worker(id int, jobs <-chan job, results chan<- res
 
 type job struct {
 	substring string
+	// filter, if non-nil, restricts matching to keys for which it
+	// returns true. Used by the glob-restricted search variants.
+	filter func(key string) bool
 }
 
 type result[Data any] struct {
@@ -114,6 +240,10 @@ type result[Data any] struct {
 }
 
 func (cm *ClosestMatch[Data]) match(searchWord string) map[string]workerResult[Data] {
+	return cm.matchFiltered(searchWord, nil)
+}
+
+func (cm *ClosestMatch[Data]) matchFiltered(searchWord string, filter func(key string) bool) map[string]workerResult[Data] {
 	searchSubstrings := cm.splitWord(strings.ToLower(searchWord))
 	searchSubstringsLen := len(searchSubstrings)
 
@@ -126,7 +256,7 @@ func (cm *ClosestMatch[Data]) match(searchWord string) map[string]workerResult[D
 	}
 
 	for substring := range searchSubstrings {
-		jobs <- job{substring: substring}
+		jobs <- job{substring: substring, filter: filter}
 	}
 	close(jobs)
 
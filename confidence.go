@@ -0,0 +1,164 @@
+package closestmatch
+
+import "sort"
+
+// ConfidentMatch is a Match additionally scored by how close its key is
+// to the search word, independent of the substring scorer that
+// produced it.
+type ConfidentMatch[Data any] struct {
+	Match[Data]
+	// Confidence is 1 minus the normalized Levenshtein distance between
+	// the search word and Key, in the range [0, 1]. 1 means an exact
+	// match; 0 means the two strings share nothing in common.
+	Confidence float64
+}
+
+// ClosestNWithConfidence searches for searchWord using the existing
+// n-gram scorer to cheaply narrow down to the max*4 most promising
+// candidates, then reranks those by normalized Levenshtein distance to
+// searchWord. This two-stage cheap-recall-then-expensive-precision
+// approach is more accurate than the n-gram score alone, at the cost of
+// only running the expensive comparison over a handful of candidates.
+//
+// While reranking, once the top max candidates found so far are known
+// (i.e. topConfidences is full), levenshteinBounded is passed the
+// largest distance at which a candidate's confidence could still reach
+// the worst (lowest) confidence among them, so it can bail out early on
+// any candidate whose distance has already grown past that bound: such
+// a candidate can't make the final cut regardless of its exact distance.
+// Before the set is full, every candidate is still a potential member
+// of the top max, so it must be scored with its true distance.
+func (cm *ClosestMatch[Data]) ClosestNWithConfidence(searchWord string, max int) []ConfidentMatch[Data] {
+	candidates := rankByWordCount[Data](cm.match(searchWord))
+	k := max * 4
+	if k > len(candidates) {
+		k = len(candidates)
+	}
+	candidates = candidates[:k]
+
+	aRunes := []rune(searchWord)
+	matches := make([]ConfidentMatch[Data], len(candidates))
+
+	topConfidences := make([]float64, 0, max)
+	worst, worstAt := 2.0, -1
+
+	for i, c := range candidates {
+		bRunes := []rune(c.Key)
+		maxLen := len(aRunes)
+		if len(bRunes) > maxLen {
+			maxLen = len(bRunes)
+		}
+
+		bound := len(aRunes) + len(bRunes)
+		if len(topConfidences) == max {
+			bound = int((1 - worst) * float64(maxLen))
+		}
+		dist := levenshteinBounded(aRunes, bRunes, bound)
+
+		matches[i] = ConfidentMatch[Data]{
+			Match:      c,
+			Confidence: confidenceFromDistance(dist, len(aRunes), len(bRunes)),
+		}
+
+		confidence := matches[i].Confidence
+		switch {
+		case len(topConfidences) < max:
+			topConfidences = append(topConfidences, confidence)
+			if confidence < worst {
+				worst, worstAt = confidence, len(topConfidences)-1
+			}
+		case confidence > worst:
+			topConfidences[worstAt] = confidence
+			worst, worstAt = topConfidences[0], 0
+			for j, v := range topConfidences {
+				if v < worst {
+					worst, worstAt = v, j
+				}
+			}
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Confidence > matches[j].Confidence
+	})
+
+	if max > len(matches) {
+		max = len(matches)
+	}
+	return matches[:max]
+}
+
+// ClosestWithinConfidence behaves like ClosestNWithConfidence but, in
+// place of a result count, returns every candidate whose confidence is
+// at or above threshold (0-1). This is useful for applications that
+// need to refuse a match outright when nothing in the corpus is close
+// enough to searchWord.
+func (cm *ClosestMatch[Data]) ClosestWithinConfidence(searchWord string, threshold float64) []ConfidentMatch[Data] {
+	all := cm.ClosestNWithConfidence(searchWord, len(cm.ID))
+
+	i := sort.Search(len(all), func(i int) bool {
+		return all[i].Confidence < threshold
+	})
+	return all[:i]
+}
+
+func confidenceFromDistance(dist, aLen, bLen int) float64 {
+	maxLen := aLen
+	if bLen > maxLen {
+		maxLen = bLen
+	}
+	if maxLen == 0 {
+		return 1
+	}
+	return 1 - float64(dist)/float64(maxLen)
+}
+
+// levenshteinBounded computes the Levenshtein distance between a and b,
+// bailing out early (returning bestSoFar+1) once every value in the
+// current row exceeds bestSoFar, since no alignment from that row can
+// beat it.
+func levenshteinBounded(a, b []rune, bestSoFar int) int {
+	if len(a) == 0 {
+		return len(b)
+	}
+	if len(b) == 0 {
+		return len(a)
+	}
+
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if curr[j] < rowMin {
+				rowMin = curr[j]
+			}
+		}
+		if rowMin > bestSoFar {
+			return bestSoFar + 1
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
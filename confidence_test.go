@@ -0,0 +1,72 @@
+package closestmatch
+
+import "testing"
+
+func TestLevenshteinBounded(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "abc", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+	}
+	for _, tt := range tests {
+		got := levenshteinBounded([]rune(tt.a), []rune(tt.b), len(tt.a)+len(tt.b))
+		if got != tt.want {
+			t.Errorf("levenshteinBounded(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestLevenshteinBoundedEarlyExit(t *testing.T) {
+	a, b := []rune("abcdefgh"), []rune("zzzzzzzz")
+
+	// The true distance is 8, with every character substituted; a tight
+	// bound of 2 should make the DP bail out as soon as it can tell the
+	// distance has exceeded it, returning bound+1 rather than 8.
+	if got := levenshteinBounded(a, b, 2); got != 3 {
+		t.Fatalf("expected early-exit result 3, got %d", got)
+	}
+	if got := levenshteinBounded(a, b, len(a)+len(b)); got != 8 {
+		t.Fatalf("expected full distance 8, got %d", got)
+	}
+}
+
+func TestClosestNWithConfidence(t *testing.T) {
+	words := map[string]int{
+		"hello world":   1,
+		"help me":       2,
+		"goodbye world": 3,
+		"hola mundo":    4,
+	}
+	cm := New(words, []int{2, 3, 4})
+
+	matches := cm.ClosestNWithConfidence("hello wrld", 2)
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %d", len(matches))
+	}
+	if matches[0].Key != "hello world" {
+		t.Fatalf("expected hello world as the top match, got %+v", matches[0])
+	}
+	for i := 1; i < len(matches); i++ {
+		if matches[i].Confidence > matches[i-1].Confidence {
+			t.Fatalf("matches are not sorted by descending confidence: %+v", matches)
+		}
+	}
+}
+
+func TestClosestWithinConfidence(t *testing.T) {
+	words := map[string]int{"hello world": 1, "goodbye world": 2}
+	cm := New(words, []int{2, 3, 4})
+
+	exact := cm.ClosestWithinConfidence("hello world", 0.99)
+	if len(exact) != 1 || exact[0].Key != "hello world" {
+		t.Fatalf("expected only the exact match, got %+v", exact)
+	}
+
+	if all := cm.ClosestWithinConfidence("hello world", 0); len(all) != len(words) {
+		t.Fatalf("expected every candidate at threshold 0, got %+v", all)
+	}
+}
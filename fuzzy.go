@@ -0,0 +1,187 @@
+package closestmatch
+
+import "sort"
+
+// FuzzyMatch is the structure used by ClosestFuzzy. It indexes the
+// possible keys verbatim (rather than by substring) so that queries can
+// be scored against them with a positional, character-by-character
+// algorithm.
+type FuzzyMatch[Data any] struct {
+	ID map[uint32]IDInfo[Data]
+}
+
+// NewFuzzy returns a new structure for performing fzf-style fuzzy
+// matches. Unlike New, it does not build a substring index: scoring is
+// done directly against each candidate key at query time.
+func NewFuzzy[Data any](possible map[string]Data) *FuzzyMatch[Data] {
+	fm := new(FuzzyMatch[Data])
+	fm.ID = make(map[uint32]IDInfo[Data], len(possible))
+	i := 0
+	for k, v := range possible {
+		fm.ID[uint32(i)] = IDInfo[Data]{Key: k, Data: v}
+		i++
+	}
+	return fm
+}
+
+const (
+	fuzzyScoreMatch       = 16
+	fuzzyScoreConsecutive = 15
+	fuzzyScoreBoundary    = 30
+	fuzzyScoreCamelCase   = 25
+	fuzzyScoreFirstChar   = 8
+	fuzzyPenaltyGapStart  = -3
+	fuzzyPenaltyGapExtend = -1
+)
+
+// ClosestFuzzy searches for searchWord using a positional fuzzy matcher
+// inspired by fzf's v2 algorithm and returns the matches sorted by score
+// descending, ties broken by shorter key length. Unlike ClosestN, every
+// rune of searchWord must occur in a candidate key, in order, for that
+// key to be considered a match at all.
+func (fm *FuzzyMatch[Data]) ClosestFuzzy(searchWord string) []Match[Data] {
+	query := []rune(searchWord)
+	if len(query) == 0 {
+		return nil
+	}
+
+	matches := make(MatchList[Data], 0, len(fm.ID))
+	for _, info := range fm.ID {
+		key := []rune(info.Key)
+		score, ok := fuzzyScore(query, key)
+		if !ok {
+			continue
+		}
+		matches = append(matches, Match[Data]{
+			Key:   info.Key,
+			Data:  info.Data,
+			Value: score,
+		})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Value != matches[j].Value {
+			return matches[i].Value > matches[j].Value
+		}
+		return len(matches[i].Key) < len(matches[j].Key)
+	})
+	return matches
+}
+
+// fuzzyScore considers every in-order placement of the runes of query
+// in key and returns the maximum-score alignment found by a dynamic
+// program over len(query) x len(key) cells. ok is false if
+// not every rune of query could be matched. Matching is case-insensitive
+// (via toLowerRune), but key keeps its original casing so charBonus can
+// still detect camelCase boundaries.
+//
+// H[i][j] is the best score aligning query[:i] to key[:j] with
+// query[i-1] matched at key[j-1]; it is minScore if no such alignment
+// exists. H[i][j] is built from H[i-1][p] for every p <= j-1 at which
+// query[i-2] could have matched, charging a gap penalty for the
+// j-1-p unmatched key runes in between (zero when p == j-1, i.e. a
+// consecutive match) and a consecutive-match bonus in that case.
+func fuzzyScore(query, key []rune) (score int, ok bool) {
+	n, m := len(query), len(key)
+	if n == 0 || m < n {
+		return 0, n == 0
+	}
+
+	const minScore = -1 << 30
+	H := make([][]int, n+1)
+	for i := range H {
+		H[i] = make([]int, m+1)
+		for j := range H[i] {
+			H[i][j] = minScore
+		}
+	}
+	H[0][0] = 0
+
+	for i := 1; i <= n; i++ {
+		for j := i; j <= m; j++ {
+			if toLowerRune(query[i-1]) != toLowerRune(key[j-1]) {
+				continue
+			}
+
+			charScore := fuzzyScoreMatch + charBonus(key, j-1)
+
+			var best int
+			if i == 1 {
+				// No rune precedes query[0], so any runes of key skipped
+				// before position j-1 are an unmatched prefix, not a gap
+				// between two matches, and are not penalized.
+				best = 0
+				charScore += fuzzyScoreFirstChar
+			} else {
+				best = minScore
+				for p := i - 1; p <= j-1; p++ {
+					if H[i-1][p] == minScore {
+						continue
+					}
+					candidate := H[i-1][p]
+					if gap := j - 1 - p; gap == 0 {
+						candidate += fuzzyScoreConsecutive
+					} else {
+						candidate += fuzzyPenaltyGapStart + fuzzyPenaltyGapExtend*(gap-1)
+					}
+					if candidate > best {
+						best = candidate
+					}
+				}
+				if best == minScore {
+					continue
+				}
+			}
+
+			H[i][j] = best + charScore
+		}
+	}
+
+	best := minScore
+	for j := n; j <= m; j++ {
+		if H[n][j] > best {
+			best = H[n][j]
+		}
+	}
+	if best == minScore {
+		return 0, false
+	}
+	return best, true
+}
+
+// charBonus returns the boundary/camelCase bonus for matching key[pos].
+// The full +30 boundary bonus is reserved for position 0 and for
+// following a non-alphanumeric character other than the named word
+// separators; following one of those separators, or a lowercase-to-
+// uppercase camelCase transition, earns the smaller +25 bonus instead.
+func charBonus(key []rune, pos int) int {
+	if pos == 0 {
+		return fuzzyScoreBoundary
+	}
+
+	prev := key[pos-1]
+	cur := key[pos]
+	switch prev {
+	case '/', '_', '-', ' ', '.':
+		return fuzzyScoreCamelCase
+	}
+	if !isAlnum(prev) {
+		return fuzzyScoreBoundary
+	}
+	if isLower(prev) && isUpper(cur) {
+		return fuzzyScoreCamelCase
+	}
+	return 0
+}
+
+func isAlnum(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+func isLower(r rune) bool {
+	return r >= 'a' && r <= 'z'
+}
+
+func isUpper(r rune) bool {
+	return r >= 'A' && r <= 'Z'
+}
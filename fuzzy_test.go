@@ -0,0 +1,48 @@
+package closestmatch
+
+import "testing"
+
+func TestClosestFuzzy(t *testing.T) {
+	words := map[string]int{
+		"hello world":   1,
+		"goodbye world": 2,
+		"help me":       3,
+	}
+	fm := NewFuzzy(words)
+
+	matches := fm.ClosestFuzzy("hlwrd")
+	if len(matches) == 0 || matches[0].Key != "hello world" {
+		t.Fatalf("expected hello world as the top match, got %+v", matches)
+	}
+
+	if matches := fm.ClosestFuzzy("zzz"); len(matches) != 0 {
+		t.Fatalf("expected no matches for a query with no subsequence, got %+v", matches)
+	}
+}
+
+func TestClosestFuzzyCamelCaseBonus(t *testing.T) {
+	// getUserData and getuserdata are both valid subsequence matches
+	// for "User", but only the camelCase key should get the camelCase
+	// boundary bonus, so it must score strictly higher.
+	words := map[string]int{"getUserData": 1, "getuserdata": 2}
+	fm := NewFuzzy(words)
+
+	matches := fm.ClosestFuzzy("User")
+	scores := make(map[string]int, len(matches))
+	for _, m := range matches {
+		scores[m.Key] = m.Value
+	}
+
+	if scores["getUserData"] <= scores["getuserdata"] {
+		t.Fatalf("expected camelCase key to score higher: %+v", scores)
+	}
+}
+
+func TestClosestFuzzyCaseInsensitive(t *testing.T) {
+	words := map[string]int{"HELLO": 1}
+	fm := NewFuzzy(words)
+
+	if matches := fm.ClosestFuzzy("hello"); len(matches) != 1 {
+		t.Fatalf("expected a case-insensitive match, got %+v", matches)
+	}
+}
@@ -0,0 +1,115 @@
+package closestmatch
+
+import (
+	"iter"
+	"regexp"
+	"strings"
+)
+
+// ClosestInGlob searches for searchWord and returns the closest match
+// among only the keys matching pattern. See Matching for the supported
+// glob syntax.
+func (cm *ClosestMatch[Data]) ClosestInGlob(searchWord, pattern string) string {
+	for _, pair := range cm.ClosestNInGlob(searchWord, pattern, 1) {
+		return pair.Key
+	}
+	return ""
+}
+
+// ClosestNInGlob searches for searchWord and returns the n closest
+// matches among only the keys matching pattern. See Matching for the
+// supported glob syntax.
+func (cm *ClosestMatch[Data]) ClosestNInGlob(searchWord, pattern string, max int) []Match[Data] {
+	re, err := compileGlob(pattern)
+	if err != nil {
+		return nil
+	}
+
+	matched := rankByWordCount[Data](cm.matchFiltered(searchWord, re.MatchString))
+	if len(matched) < max {
+		max = len(matched)
+	}
+	return matched[:max]
+}
+
+// Matching lists every key matching pattern, along with its associated
+// data.
+//
+// pattern is a glob supporting:
+//
+//   - '*', which matches any run of characters within a single
+//     '/'-separated path segment
+//   - '**', which matches any run of characters, including '/', so it
+//     can span multiple path segments
+//   - '?', which matches any single character other than '/'
+//   - '[...]' character classes, e.g. '[abc]' or the negated '[!abc]'
+//
+// A pattern such as "users/*/profile" matches "users/42/profile" but
+// not "users/42/settings/profile", while "assets/**/*.png" matches
+// "assets/icons/a.png" and "assets/icons/small/a.png" alike.
+func (cm *ClosestMatch[Data]) Matching(pattern string) iter.Seq2[string, Data] {
+	re, err := compileGlob(pattern)
+	return func(yield func(string, Data) bool) {
+		if err != nil {
+			return
+		}
+		for _, info := range cm.ID {
+			if !re.MatchString(info.Key) {
+				continue
+			}
+			if !yield(info.Key, info.Data) {
+				return
+			}
+		}
+	}
+}
+
+// compileGlob translates a glob pattern into an anchored regular
+// expression implementing the syntax documented on Matching.
+func compileGlob(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch r {
+		case '*':
+			if i+1 < len(runes) && runes[i+1] == '*' {
+				b.WriteString(".*")
+				i++
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			start := i + 1
+			negate := start < len(runes) && (runes[start] == '!' || runes[start] == '^')
+			if negate {
+				start++
+			}
+			j := start
+			for j < len(runes) && runes[j] != ']' {
+				j++
+			}
+			if j >= len(runes) {
+				// Unterminated class: treat '[' literally.
+				b.WriteString(regexp.QuoteMeta("["))
+				continue
+			}
+			b.WriteString("[")
+			if negate {
+				b.WriteString("^")
+			}
+			b.WriteString(regexp.QuoteMeta(string(runes[start:j])))
+			b.WriteString("]")
+			i = j
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,47 @@
+package closestmatch
+
+import "testing"
+
+func collectMatching[Data any](cm *ClosestMatch[Data], pattern string) []string {
+	var keys []string
+	cm.Matching(pattern)(func(k string, _ Data) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return keys
+}
+
+func TestMatching(t *testing.T) {
+	words := map[string]int{
+		"users/42/profile":          1,
+		"users/42/settings/profile": 2,
+		"assets/icons/a.png":        3,
+		"assets/icons/small/a.png":  4,
+		"assets/readme.md":          5,
+	}
+	cm := New(words, []int{2, 3})
+
+	if got := collectMatching(cm, "users/*/profile"); len(got) != 1 || got[0] != "users/42/profile" {
+		t.Fatalf("users/*/profile: got %v", got)
+	}
+
+	if got := collectMatching(cm, "assets/**/*.png"); len(got) != 2 {
+		t.Fatalf("assets/**/*.png: got %v", got)
+	}
+
+	if got := collectMatching(cm, "assets/[r]*"); len(got) != 1 || got[0] != "assets/readme.md" {
+		t.Fatalf("assets/[r]*: got %v", got)
+	}
+}
+
+func TestClosestInGlob(t *testing.T) {
+	words := map[string]int{
+		"users/42/profile":          1,
+		"users/42/settings/profile": 2,
+	}
+	cm := New(words, []int{2, 3})
+
+	if got := cm.ClosestInGlob("profile", "users/*/profile"); got != "users/42/profile" {
+		t.Fatalf("ClosestInGlob: got %q", got)
+	}
+}
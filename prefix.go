@@ -0,0 +1,77 @@
+package closestmatch
+
+import (
+	"sort"
+	"strings"
+)
+
+// MatchPrefix returns every key that has prefix as a case-insensitive
+// prefix, in ascending order of the lowercased keys, together with their
+// associated data.
+func (cm *ClosestMatch[Data]) MatchPrefix(prefix string) []Match[Data] {
+	cm.ensurePrefixIndex()
+
+	lower := strings.ToLower(prefix)
+	matches := make([]Match[Data], 0)
+	for i := sort.SearchStrings(cm.PrefixKeys, lower); i < len(cm.PrefixKeys) && strings.HasPrefix(cm.PrefixKeys[i], lower); i++ {
+		info := cm.ID[cm.PrefixIDs[i]]
+		matches = append(matches, Match[Data]{Key: info.Key, Data: info.Data})
+	}
+	return matches
+}
+
+// MatchPrefixCmp reports how the closest key in the corpus compares to
+// prefix: -1 if every key sorts before prefix, 0 if some key has
+// prefix as a case-insensitive prefix, and 1 if the closest key sorts
+// after prefix. This is useful for range queries and binary-search-like
+// navigation over the key space. An empty corpus reports 0.
+func (cm *ClosestMatch[Data]) MatchPrefixCmp(prefix string) int {
+	cm.ensurePrefixIndex()
+	if len(cm.PrefixKeys) == 0 {
+		return 0
+	}
+
+	lower := strings.ToLower(prefix)
+	i := sort.SearchStrings(cm.PrefixKeys, lower)
+	if i < len(cm.PrefixKeys) && strings.HasPrefix(cm.PrefixKeys[i], lower) {
+		return 0
+	}
+	if i >= len(cm.PrefixKeys) {
+		return -1
+	}
+	return 1
+}
+
+// ensurePrefixIndex lazily builds the sorted prefix index on first use,
+// so that constructing a ClosestMatch stays cheap for callers who never
+// need prefix lookups.
+func (cm *ClosestMatch[Data]) ensurePrefixIndex() {
+	cm.prefixOnce.Do(func() {
+		if cm.PrefixKeys != nil {
+			return
+		}
+
+		cm.PrefixKeys = make([]string, 0, len(cm.ID))
+		cm.PrefixIDs = make([]uint32, 0, len(cm.ID))
+		for id, info := range cm.ID {
+			cm.PrefixKeys = append(cm.PrefixKeys, strings.ToLower(info.Key))
+			cm.PrefixIDs = append(cm.PrefixIDs, id)
+		}
+		sort.Sort(prefixIndex[Data]{cm})
+	})
+}
+
+// prefixIndex sorts a ClosestMatch's PrefixKeys and PrefixIDs together
+// by key.
+type prefixIndex[Data any] struct {
+	cm *ClosestMatch[Data]
+}
+
+func (p prefixIndex[Data]) Len() int { return len(p.cm.PrefixKeys) }
+func (p prefixIndex[Data]) Less(i, j int) bool {
+	return p.cm.PrefixKeys[i] < p.cm.PrefixKeys[j]
+}
+func (p prefixIndex[Data]) Swap(i, j int) {
+	p.cm.PrefixKeys[i], p.cm.PrefixKeys[j] = p.cm.PrefixKeys[j], p.cm.PrefixKeys[i]
+	p.cm.PrefixIDs[i], p.cm.PrefixIDs[j] = p.cm.PrefixIDs[j], p.cm.PrefixIDs[i]
+}
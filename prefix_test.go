@@ -0,0 +1,32 @@
+package closestmatch
+
+import "testing"
+
+func TestMatchPrefix(t *testing.T) {
+	words := map[string]int{"apple": 1, "application": 2, "banana": 3, "Apex": 4}
+	cm := New(words, []int{2, 3})
+
+	matches := cm.MatchPrefix("app")
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches for prefix %q, got %+v", "app", matches)
+	}
+
+	if matches := cm.MatchPrefix("APP"); len(matches) != 2 {
+		t.Fatalf("expected MatchPrefix to be case-insensitive, got %+v", matches)
+	}
+}
+
+func TestMatchPrefixCmp(t *testing.T) {
+	words := map[string]int{"apple": 1, "banana": 2}
+	cm := New(words, []int{2, 3})
+
+	if cmp := cm.MatchPrefixCmp("app"); cmp != 0 {
+		t.Fatalf("expected 0 for an existing prefix, got %d", cmp)
+	}
+	if cmp := cm.MatchPrefixCmp("000"); cmp != 1 {
+		t.Fatalf("expected 1 for a prefix before every key, got %d", cmp)
+	}
+	if cmp := cm.MatchPrefixCmp("zzz"); cmp != -1 {
+		t.Fatalf("expected -1 for a prefix after every key, got %d", cmp)
+	}
+}
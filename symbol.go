@@ -0,0 +1,243 @@
+package closestmatch
+
+import "sort"
+
+// symbolRole classifies each rune of an identifier for the purposes of
+// SymbolMatch's scoring.
+type symbolRole uint8
+
+const (
+	// roleSeparator runes (_, -, /, .) are skippable and never matched.
+	roleSeparator symbolRole = iota
+	// roleHead runes start a segment: the first rune of the key, a
+	// rune following a separator, or an uppercase rune following a
+	// lowercase one (a camelCase boundary).
+	roleHead
+	// roleTail is every other rune.
+	roleTail
+)
+
+// symbolEntry is a key indexed by SymbolMatch, along with the role of
+// each of its runes.
+type symbolEntry[Data any] struct {
+	Key   string
+	Runes []rune
+	Roles []symbolRole
+	Data  Data
+}
+
+// SymbolMatch is a fuzzy matcher tailored to code-symbol corpora, where
+// keys are identifiers such as getUserByID or parse_http_header whose
+// camelCase and snake_case boundaries carry meaning.
+type SymbolMatch[Data any] struct {
+	ID map[uint32]symbolEntry[Data]
+}
+
+// NewSymbol returns a new structure for performing symbol-aware fuzzy
+// matches, segmenting each key into camelCase/snake_case/kebab-case
+// words at index time.
+func NewSymbol[Data any](possible map[string]Data) *SymbolMatch[Data] {
+	sm := new(SymbolMatch[Data])
+	sm.ID = make(map[uint32]symbolEntry[Data], len(possible))
+	i := 0
+	for k, v := range possible {
+		runes := []rune(k)
+		sm.ID[uint32(i)] = symbolEntry[Data]{
+			Key:   k,
+			Runes: runes,
+			Roles: symbolRoles(runes),
+			Data:  v,
+		}
+		i++
+	}
+	return sm
+}
+
+// isSeparator reports whether r splits an identifier into segments.
+func isSeparator(r rune) bool {
+	switch r {
+	case '_', '-', '/', '.':
+		return true
+	}
+	return false
+}
+
+// symbolRoles classifies every rune of an identifier as described on
+// symbolRole.
+func symbolRoles(runes []rune) []symbolRole {
+	roles := make([]symbolRole, len(runes))
+	for i, r := range runes {
+		switch {
+		case isSeparator(r):
+			roles[i] = roleSeparator
+		case i == 0:
+			roles[i] = roleHead
+		case isSeparator(runes[i-1]):
+			roles[i] = roleHead
+		case isLower(runes[i-1]) && isUpper(r):
+			roles[i] = roleHead
+		default:
+			roles[i] = roleTail
+		}
+	}
+	return roles
+}
+
+// Segments splits key into the word pieces SymbolMatch derives from it:
+// camelCase, snake_case, kebab-case and path boundaries each start a
+// new segment.
+func Segments(key string) []string {
+	runes := []rune(key)
+	roles := symbolRoles(runes)
+
+	var segments []string
+	var cur []rune
+	for i, r := range runes {
+		switch {
+		case roles[i] == roleSeparator:
+			if len(cur) > 0 {
+				segments = append(segments, string(cur))
+				cur = nil
+			}
+		case roles[i] == roleHead && len(cur) > 0:
+			segments = append(segments, string(cur))
+			cur = []rune{r}
+		default:
+			cur = append(cur, r)
+		}
+	}
+	if len(cur) > 0 {
+		segments = append(segments, string(cur))
+	}
+	return segments
+}
+
+const (
+	symbolScoreMatch       = 10
+	symbolScoreHead        = 25
+	symbolScoreConsecutive = 5
+	symbolPenaltyHeadSkip  = -20
+)
+
+// ClosestSymbol searches for searchWord using the role-aware scorer
+// described on SymbolMatch and returns the matches sorted by score
+// descending, ties broken by shorter key length. Query runes must
+// occur in every matching key in order, though not contiguously.
+func (sm *SymbolMatch[Data]) ClosestSymbol(searchWord string) []Match[Data] {
+	query := []rune(searchWord)
+	if len(query) == 0 {
+		return nil
+	}
+
+	matches := make(MatchList[Data], 0, len(sm.ID))
+	for _, entry := range sm.ID {
+		score, ok := symbolScore(query, entry.Runes, entry.Roles)
+		if !ok {
+			continue
+		}
+		matches = append(matches, Match[Data]{
+			Key:   entry.Key,
+			Data:  entry.Data,
+			Value: score,
+		})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Value != matches[j].Value {
+			return matches[i].Value > matches[j].Value
+		}
+		return len(matches[i].Key) < len(matches[j].Key)
+	})
+	return matches
+}
+
+// symbolScore is the SymbolMatch analogue of fuzzyScore: a DP over
+// len(query) x len(key) cells awards symbolScoreMatch per matched rune,
+// symbolScoreHead extra when the match falls on a roleHead rune,
+// symbolScoreConsecutive extra for adjacent matches, and charges
+// symbolPenaltyHeadSkip for every roleHead rune skipped between two
+// matches. Matching is case-insensitive; query runes are compared
+// against key runes via toLowerRune so identifiers keep their role
+// classification (which depends on original case).
+func symbolScore(query, key []rune, roles []symbolRole) (score int, ok bool) {
+	n, m := len(query), len(key)
+	if n == 0 || m < n {
+		return 0, n == 0
+	}
+
+	headBefore := make([]int, m+1)
+	for i, role := range roles {
+		headBefore[i+1] = headBefore[i]
+		if role == roleHead {
+			headBefore[i+1]++
+		}
+	}
+
+	const minScore = -1 << 30
+	H := make([][]int, n+1)
+	for i := range H {
+		H[i] = make([]int, m+1)
+		for j := range H[i] {
+			H[i][j] = minScore
+		}
+	}
+	H[0][0] = 0
+
+	for i := 1; i <= n; i++ {
+		for j := i; j <= m; j++ {
+			if roles[j-1] == roleSeparator || toLowerRune(query[i-1]) != toLowerRune(key[j-1]) {
+				continue
+			}
+
+			charScore := symbolScoreMatch
+			if roles[j-1] == roleHead {
+				charScore += symbolScoreHead
+			}
+
+			var best int
+			if i == 1 {
+				best = 0
+			} else {
+				best = minScore
+				for p := i - 1; p <= j-1; p++ {
+					if H[i-1][p] == minScore {
+						continue
+					}
+					candidate := H[i-1][p]
+					if p == j-1 {
+						candidate += symbolScoreConsecutive
+					} else {
+						headsSkipped := headBefore[j-1] - headBefore[p]
+						candidate += symbolPenaltyHeadSkip * headsSkipped
+					}
+					if candidate > best {
+						best = candidate
+					}
+				}
+				if best == minScore {
+					continue
+				}
+			}
+
+			H[i][j] = best + charScore
+		}
+	}
+
+	best := minScore
+	for j := n; j <= m; j++ {
+		if H[n][j] > best {
+			best = H[n][j]
+		}
+	}
+	if best == minScore {
+		return 0, false
+	}
+	return best, true
+}
+
+func toLowerRune(r rune) rune {
+	if isUpper(r) {
+		return r + ('a' - 'A')
+	}
+	return r
+}
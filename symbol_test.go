@@ -0,0 +1,58 @@
+package closestmatch
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClosestSymbol(t *testing.T) {
+	words := map[string]int{
+		"getUserByID":       1,
+		"getUserByName":     2,
+		"parse_http_header": 3,
+		"ParseJSONBody":     4,
+	}
+	sm := NewSymbol(words)
+
+	if res := sm.ClosestSymbol("gubid"); len(res) == 0 || res[0].Key != "getUserByID" {
+		t.Fatalf("expected getUserByID as the top match, got %+v", res)
+	}
+
+	if res := sm.ClosestSymbol("phh"); len(res) == 0 || res[0].Key != "parse_http_header" {
+		t.Fatalf("expected parse_http_header as the top match, got %+v", res)
+	}
+
+	if res := sm.ClosestSymbol("zzz"); len(res) != 0 {
+		t.Fatalf("expected no matches for a query with no subsequence, got %+v", res)
+	}
+}
+
+func TestClosestSymbolHeadBonus(t *testing.T) {
+	// "gUBI" hits the Head rune of every segment in getUserByID, so it
+	// should score strictly higher than an equal-length query that
+	// lands on Tail runes instead.
+	words := map[string]int{"getUserByID": 1}
+	sm := NewSymbol(words)
+
+	headScore := sm.ClosestSymbol("gUBI")[0].Value
+	tailScore := sm.ClosestSymbol("etse")[0].Value
+	if headScore <= tailScore {
+		t.Fatalf("expected head-aligned query to score higher: head=%d tail=%d", headScore, tailScore)
+	}
+}
+
+func TestSegments(t *testing.T) {
+	tests := []struct {
+		key  string
+		want []string
+	}{
+		{"parse_http_header", []string{"parse", "http", "header"}},
+		{"getUserByID", []string{"get", "User", "By", "ID"}},
+		{"kebab-case-name", []string{"kebab", "case", "name"}},
+	}
+	for _, tt := range tests {
+		if got := Segments(tt.key); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Segments(%q) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+}
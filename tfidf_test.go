@@ -0,0 +1,61 @@
+package closestmatch
+
+import "testing"
+
+// TestTFIDFWeighsRareSubstringHigher verifies the core IDF computation:
+// a substring shared by most of the corpus must score lower than one
+// found in only a couple of keys, which in turn scores lower than one
+// unique to a single key.
+func TestTFIDFWeighsRareSubstringHigher(t *testing.T) {
+	words := map[string]int{
+		"aaxx": 1,
+		"aayy": 2,
+		"aazz": 3,
+		"aaww": 4,
+		"bbzz": 5,
+	}
+	cm := New(words, []int{2}, WithScoring(ScoringTFIDF))
+
+	common := cm.IDF["aa"] // appears in 4 of 5 keys
+	medium := cm.IDF["zz"] // appears in 2 of 5 keys
+	rare := cm.IDF["xx"]   // appears in 1 of 5 keys
+
+	if !(common < medium && medium < rare) {
+		t.Fatalf("expected IDF(aa)=%v < IDF(zz)=%v < IDF(xx)=%v", common, medium, rare)
+	}
+}
+
+// TestTFIDFRerankDiffersFromLegacy verifies that ScoringTFIDF reranks
+// candidates differently from ScoringLegacy. Four keys all share the
+// same single common substring ("cat") with the query and are
+// otherwise identical in length and ID count, so ScoringLegacy, which
+// weighs every matched substring equally, scores them in an exact tie.
+// ScoringTFIDF additionally normalizes by each key's substring IDF
+// norm, so catcat00 (which has fewer distinct substrings, and so a
+// smaller norm) breaks out ahead of the rest instead of tying with
+// them.
+func TestTFIDFRerankDiffersFromLegacy(t *testing.T) {
+	words := map[string]int{
+		"catcat00": 1,
+		"dogcat00": 2,
+		"foocat00": 3,
+		"barcat00": 4,
+	}
+	legacy := New(words, []int{3}, WithScoring(ScoringLegacy))
+	tfidf := New(words, []int{3}, WithScoring(ScoringTFIDF))
+
+	legacyMatches := legacy.ClosestN("cat", 4)
+	for _, m := range legacyMatches[1:] {
+		if m.Value != legacyMatches[0].Value {
+			t.Fatalf("expected legacy scoring to tie every candidate, got %+v", legacyMatches)
+		}
+	}
+
+	tfidfMatches := tfidf.ClosestN("cat", 4)
+	if tfidfMatches[0].Key != "catcat00" {
+		t.Fatalf("expected TF-IDF's norm to favor the key with fewer distinct substrings, got %+v", tfidfMatches)
+	}
+	if tfidfMatches[0].Value == tfidfMatches[1].Value {
+		t.Fatalf("expected TF-IDF scoring to break the legacy tie, got %+v", tfidfMatches)
+	}
+}